@@ -0,0 +1,66 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"quelpoke/internal/pokeapi"
+	"quelpoke/internal/pokeclient"
+)
+
+// pokemonResponse is the JSON shape served by /api/pokemon and by / when the
+// client asks for application/json.
+type pokemonResponse struct {
+	ID         uint64         `json:"id"`
+	Name       string         `json:"name"`
+	Types      []string       `json:"types"`
+	Stats      map[string]int `json:"stats"`
+	Sprite     string         `json:"sprite"`
+	Evolutions []string       `json:"evolutions"`
+}
+
+func newPokemonResponse(poke pokeapi.Pokemon, evolutions []string) pokemonResponse {
+	return pokemonResponse{
+		ID:         poke.ID,
+		Name:       poke.Name,
+		Types:      pokemonTypes(poke),
+		Stats:      pokemonStats(poke),
+		Sprite:     poke.Sprites.Other.OfficialArtwork.FrontDefault,
+		Evolutions: evolutions,
+	}
+}
+
+// apiPokemon handles GET /api/pokemon?name=... and responds with JSON
+// regardless of the Accept header; upstream errors are reported as a
+// plain-text 500.
+func apiPokemon(client *pokeclient.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name := r.URL.Query().Get("name")
+		if name == "" {
+			name = "cafard"
+		}
+
+		pid := pokemonID(name, 151)
+		poke, evolutions, err := fetchPokemon(r.Context(), client, pid)
+		if err != nil {
+			http.Error(w, err.Error(), 500)
+			return
+		}
+
+		writeJSON(w, newPokemonResponse(poke, evolutions))
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		http.Error(w, err.Error(), 500)
+	}
+}
+
+// wantsJSON reports whether the request's Accept header mentions
+// application/json; quality values and the order of media types are ignored.
+func wantsJSON(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), "application/json")
+}
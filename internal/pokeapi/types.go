@@ -0,0 +1,117 @@
+// Package pokeapi defines the typed shapes returned by pokeapi.co that the
+// rest of quelpoke needs. Fields are kept close to the upstream JSON and
+// cover only part of each upstream resource.
+package pokeapi
+
+// NamedAPIResource is the {name, url} pair pokeapi.co uses everywhere it
+// references another resource without embedding it.
+type NamedAPIResource struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// APIResource is like NamedAPIResource but for references that carry no name
+// (e.g. a species' evolution_chain).
+type APIResource struct {
+	URL string `json:"url"`
+}
+
+// NamedAPIResourceList is the paginated envelope returned by list endpoints
+// such as /pokemon or /location-area.
+type NamedAPIResourceList struct {
+	Count    int                `json:"count"`
+	Next     *string            `json:"next"`
+	Previous *string            `json:"previous"`
+	Results  []NamedAPIResource `json:"results"`
+}
+
+// Pokemon is the response shape of /pokemon/{id or name}.
+type Pokemon struct {
+	ID             uint64           `json:"id"`
+	Name           string           `json:"name"`
+	BaseExperience int              `json:"base_experience"`
+	Types          []PokemonType    `json:"types"`
+	Stats          []PokemonStat    `json:"stats"`
+	Sprites        PokemonSprites   `json:"sprites"`
+	Species        NamedAPIResource `json:"species"`
+}
+
+// PokemonType pairs a type slot with the named type resource.
+type PokemonType struct {
+	Slot int              `json:"slot"`
+	Type NamedAPIResource `json:"type"`
+}
+
+// PokemonStat pairs a base stat value with the named stat resource.
+type PokemonStat struct {
+	BaseStat int              `json:"base_stat"`
+	Effort   int              `json:"effort"`
+	Stat     NamedAPIResource `json:"stat"`
+}
+
+// PokemonSprites only keeps the sprite URLs quelpoke renders.
+type PokemonSprites struct {
+	Other struct {
+		OfficialArtwork struct {
+			FrontDefault string `json:"front_default"`
+		} `json:"official-artwork"`
+	} `json:"other"`
+}
+
+// PokemonSpecies is the response shape of the species endpoint a Pokemon
+// links to; it's mainly a hop towards the evolution chain.
+type PokemonSpecies struct {
+	Name           string      `json:"name"`
+	EvolutionChain APIResource `json:"evolution_chain"`
+}
+
+// EvolutionChain is the response shape of /evolution-chain/{id}.
+type EvolutionChain struct {
+	Chain ChainLink `json:"chain"`
+}
+
+// ChainLink is one node of an evolution chain, recursing through EvolvesTo.
+type ChainLink struct {
+	Species   NamedAPIResource `json:"species"`
+	EvolvesTo []ChainLink      `json:"evolves_to"`
+}
+
+// Names flattens a chain into a depth-first (pre-order) list of species
+// names, e.g.
+// ["bulbasaur", "ivysaur", "venusaur"].
+func (c ChainLink) Names() []string {
+	names := []string{c.Species.Name}
+	for _, next := range c.EvolvesTo {
+		names = append(names, next.Names()...)
+	}
+	return names
+}
+
+// LocationArea is the response shape of /location-area/{name}.
+type LocationArea struct {
+	ID                int                `json:"id"`
+	Name              string             `json:"name"`
+	PokemonEncounters []PokemonEncounter `json:"pokemon_encounters"`
+}
+
+// PokemonEncounter describes a pokemon that can be found in a location area.
+type PokemonEncounter struct {
+	Pokemon        NamedAPIResource         `json:"pokemon"`
+	VersionDetails []VersionEncounterDetail `json:"version_details"`
+}
+
+// VersionEncounterDetail groups the encounter odds for a single game version.
+type VersionEncounterDetail struct {
+	Version          NamedAPIResource `json:"version"`
+	MaxChance        int              `json:"max_chance"`
+	EncounterDetails []Encounter      `json:"encounter_details"`
+}
+
+// Encounter is one way a pokemon can be encountered in a location area.
+type Encounter struct {
+	Chance          int                `json:"chance"`
+	ConditionValues []NamedAPIResource `json:"condition_values"`
+	MaxLevel        int                `json:"max_level"`
+	Method          NamedAPIResource   `json:"method"`
+	MinLevel        int                `json:"min_level"`
+}
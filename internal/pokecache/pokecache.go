@@ -0,0 +1,79 @@
+// Package pokecache is a small TTL-bounded in-memory cache used to avoid
+// re-fetching the same pokeapi.co URL over and over.
+package pokecache
+
+import (
+	"sync"
+	"time"
+)
+
+type entry struct {
+	createdAt time.Time
+	val       []byte
+}
+
+// Cache maps a URL to the raw bytes last fetched for it. Entries older than
+// the configured interval are reaped in the background.
+type Cache struct {
+	mu      sync.RWMutex
+	entries map[string]entry
+	done    chan struct{}
+}
+
+// NewCache builds a Cache and starts a goroutine that reaps entries older
+// than interval, waking up every interval to sweep. Call Stop to shut that
+// goroutine down.
+func NewCache(interval time.Duration) *Cache {
+	c := &Cache{
+		entries: make(map[string]entry),
+		done:    make(chan struct{}),
+	}
+	go c.reapLoop(interval)
+	return c
+}
+
+// Stop terminates the background reap loop.
+func (c *Cache) Stop() {
+	close(c.done)
+}
+
+// Add stores val under key, stamped with the current time.
+func (c *Cache) Add(key string, val []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = entry{createdAt: time.Now(), val: val}
+}
+
+// Get returns the cached value for key, if present.
+func (c *Cache) Get(key string) ([]byte, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	return e.val, true
+}
+
+func (c *Cache) reapLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.reap(interval)
+		case <-c.done:
+			return
+		}
+	}
+}
+
+func (c *Cache) reap(interval time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, e := range c.entries {
+		if time.Since(e.createdAt) >= interval {
+			delete(c.entries, key)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package pokecache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAddGet(t *testing.T) {
+	c := NewCache(time.Hour)
+	c.Add("https://pokeapi.co/api/v2/pokemon/1", []byte("bulbasaur"))
+
+	val, ok := c.Get("https://pokeapi.co/api/v2/pokemon/1")
+	if !ok {
+		t.Fatal("expected cache hit")
+	}
+	if string(val) != "bulbasaur" {
+		t.Fatalf("got %q, want %q", val, "bulbasaur")
+	}
+
+	if _, ok := c.Get("missing"); ok {
+		t.Fatal("expected cache miss for unknown key")
+	}
+}
+
+func TestReapEvictsExpiredEntries(t *testing.T) {
+	interval := 20 * time.Millisecond
+	c := NewCache(interval)
+	c.Add("key", []byte("val"))
+
+	time.Sleep(interval * 3)
+
+	if _, ok := c.Get("key"); ok {
+		t.Fatal("expected entry to be reaped after interval elapsed")
+	}
+}
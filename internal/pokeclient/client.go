@@ -0,0 +1,218 @@
+// Package pokeclient talks to pokeapi.co on behalf of quelpoke and decodes
+// the responses into internal/pokeapi types.
+package pokeclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"quelpoke/internal/pokeapi"
+	"quelpoke/internal/pokecache"
+)
+
+const baseURL = "https://pokeapi.co/api/v2"
+
+const (
+	defaultCacheTTL    = 5 * time.Minute
+	defaultHTTPTimeout = 10 * time.Second
+)
+
+// Client is a thin wrapper around pokeapi.co's REST API, backed by a TTL
+// cache so repeated lookups don't hit the network again.
+type Client struct {
+	httpClient *http.Client
+	cache      *pokecache.Cache
+}
+
+// NewClient builds a Client ready to use. The cache TTL defaults to 5
+// minutes (override with CACHE_TTL, a time.ParseDuration string such as
+// "30s") and the per-request timeout defaults to 10 seconds (override with
+// HTTP_TIMEOUT).
+func NewClient() *Client {
+	return &Client{
+		httpClient: &http.Client{Timeout: httpTimeout()},
+		cache:      pokecache.NewCache(cacheTTL()),
+	}
+}
+
+// Close stops the client's cache reaper goroutine.
+func (c *Client) Close() {
+	c.cache.Stop()
+}
+
+func cacheTTL() time.Duration {
+	raw := os.Getenv("CACHE_TTL")
+	if raw == "" {
+		return defaultCacheTTL
+	}
+	if d, err := time.ParseDuration(raw); err == nil {
+		return d
+	}
+	if secs, err := strconv.Atoi(raw); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	return defaultCacheTTL
+}
+
+// validatePageURL rejects any URL that doesn't point at pokeapi.co, so a
+// client-supplied page cursor (e.g. the "page" query param on /locations)
+// can't be used to make the server fetch arbitrary hosts.
+func validatePageURL(pageURL string) error {
+	if !strings.HasPrefix(pageURL, baseURL) {
+		return fmt.Errorf("pokeclient: page url %q is not a pokeapi.co url", pageURL)
+	}
+	return nil
+}
+
+// Timeout returns the per-upstream-request timeout a Client is configured
+// with (HTTP_TIMEOUT, default 10s). Callers that chain several requests per
+// handler (e.g. pokemon → species → evolution-chain) can use this to size
+// their own timeouts for the worst case.
+func Timeout() time.Duration {
+	return httpTimeout()
+}
+
+func httpTimeout() time.Duration {
+	raw := os.Getenv("HTTP_TIMEOUT")
+	if raw == "" {
+		return defaultHTTPTimeout
+	}
+	if d, err := time.ParseDuration(raw); err == nil {
+		return d
+	}
+	if secs, err := strconv.Atoi(raw); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	return defaultHTTPTimeout
+}
+
+// GetPokemon fetches /pokemon/{id}.
+func (c *Client) GetPokemon(ctx context.Context, id uint64) (pokeapi.Pokemon, error) {
+	var poke pokeapi.Pokemon
+	url := fmt.Sprintf("%s/pokemon/%d", baseURL, id)
+	if err := c.getJSON(ctx, url, &poke); err != nil {
+		return pokeapi.Pokemon{}, err
+	}
+	return poke, nil
+}
+
+// GetPokemonByName fetches /pokemon/{name}; pokeapi.co's pokemon endpoint
+// accepts either a numeric id or the species name.
+func (c *Client) GetPokemonByName(ctx context.Context, name string) (pokeapi.Pokemon, error) {
+	var poke pokeapi.Pokemon
+	reqURL := fmt.Sprintf("%s/pokemon/%s", baseURL, url.PathEscape(name))
+	if err := c.getJSON(ctx, reqURL, &poke); err != nil {
+		return pokeapi.Pokemon{}, err
+	}
+	return poke, nil
+}
+
+// GetSpecies fetches the species resource a Pokemon links to.
+func (c *Client) GetSpecies(ctx context.Context, url string) (pokeapi.PokemonSpecies, error) {
+	var species pokeapi.PokemonSpecies
+	if err := c.getJSON(ctx, url, &species); err != nil {
+		return pokeapi.PokemonSpecies{}, err
+	}
+	return species, nil
+}
+
+// GetEvolutionChain fetches the evolution chain a species links to.
+func (c *Client) GetEvolutionChain(ctx context.Context, url string) (pokeapi.EvolutionChain, error) {
+	var chain pokeapi.EvolutionChain
+	if err := c.getJSON(ctx, url, &chain); err != nil {
+		return pokeapi.EvolutionChain{}, err
+	}
+	return chain, nil
+}
+
+// ListPokemon fetches a page of the /pokemon list. Pass an empty pageURL to
+// get the first page, or a Next/Previous URL from a prior result to page
+// through it.
+func (c *Client) ListPokemon(ctx context.Context, pageURL string) (pokeapi.NamedAPIResourceList, error) {
+	if pageURL == "" {
+		pageURL = baseURL + "/pokemon"
+	}
+	if err := validatePageURL(pageURL); err != nil {
+		return pokeapi.NamedAPIResourceList{}, err
+	}
+	var list pokeapi.NamedAPIResourceList
+	if err := c.getJSON(ctx, pageURL, &list); err != nil {
+		return pokeapi.NamedAPIResourceList{}, err
+	}
+	return list, nil
+}
+
+// ListLocationAreas fetches a page of the /location-area list. Pass an
+// empty pageURL to get the first page, or a Next/Previous URL from a prior
+// result to page through it.
+func (c *Client) ListLocationAreas(ctx context.Context, pageURL string) (pokeapi.NamedAPIResourceList, error) {
+	if pageURL == "" {
+		pageURL = baseURL + "/location-area"
+	}
+	if err := validatePageURL(pageURL); err != nil {
+		return pokeapi.NamedAPIResourceList{}, err
+	}
+	var list pokeapi.NamedAPIResourceList
+	if err := c.getJSON(ctx, pageURL, &list); err != nil {
+		return pokeapi.NamedAPIResourceList{}, err
+	}
+	return list, nil
+}
+
+// GetLocationArea fetches /location-area/{name}, including the pokemon that
+// can be encountered there.
+func (c *Client) GetLocationArea(ctx context.Context, name string) (pokeapi.LocationArea, error) {
+	var area pokeapi.LocationArea
+	reqURL := fmt.Sprintf("%s/location-area/%s", baseURL, url.PathEscape(name))
+	if err := c.getJSON(ctx, reqURL, &area); err != nil {
+		return pokeapi.LocationArea{}, err
+	}
+	return area, nil
+}
+
+func (c *Client) getJSON(ctx context.Context, url string, dest any) error {
+	body, err := c.get(ctx, url)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, dest)
+}
+
+// get fetches url, serving from the cache when possible and populating it
+// otherwise.
+func (c *Client) get(ctx context.Context, url string) ([]byte, error) {
+	if cached, ok := c.cache.Get(url); ok {
+		return cached, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("pokeclient: %s returned status %d", url, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	c.cache.Add(url, body)
+	return body, nil
+}
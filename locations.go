@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"quelpoke/internal/pokeclient"
+)
+
+// locationsIndex handles GET /locations?page=<url>, listing location areas
+// 20 at a time with next/previous links built from the NamedAPIResourceList
+// pagination cursors.
+func locationsIndex(client *pokeclient.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		list, err := client.ListLocationAreas(r.Context(), r.URL.Query().Get("page"))
+		if err != nil {
+			http.Error(w, err.Error(), 500)
+			return
+		}
+
+		fmt.Fprint(w, "<h1>Location areas</h1><ul>")
+		for _, area := range list.Results {
+			fmt.Fprintf(w, `<li><a href="/locations/%s">%s</a></li>`, html.EscapeString(area.Name), html.EscapeString(area.Name))
+		}
+		fmt.Fprint(w, "</ul>")
+
+		if list.Previous != nil {
+			fmt.Fprintf(w, `<a href="/locations?page=%s">previous</a> `, url.QueryEscape(*list.Previous))
+		}
+		if list.Next != nil {
+			fmt.Fprintf(w, `<a href="/locations?page=%s">next</a>`, url.QueryEscape(*list.Next))
+		}
+	}
+}
+
+// locationArea handles GET /locations/{area}, listing the pokemon that can
+// be encountered there along with their chance and encounter method.
+func locationArea(client *pokeclient.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimPrefix(r.URL.Path, "/locations/")
+		if name == "" {
+			http.NotFound(w, r)
+			return
+		}
+
+		area, err := client.GetLocationArea(r.Context(), name)
+		if err != nil {
+			http.Error(w, err.Error(), 500)
+			return
+		}
+
+		fmt.Fprintf(w, "<h1>%s</h1><ul>", html.EscapeString(area.Name))
+		for _, encounter := range area.PokemonEncounters {
+			for _, version := range encounter.VersionDetails {
+				for _, detail := range version.EncounterDetails {
+					fmt.Fprintf(w, "<li>%s — %d%% via %s</li>",
+						html.EscapeString(encounter.Pokemon.Name),
+						detail.Chance,
+						html.EscapeString(detail.Method.Name))
+				}
+			}
+		}
+		fmt.Fprint(w, "</ul>")
+	}
+}
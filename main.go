@@ -1,17 +1,24 @@
 package main
 
 import (
+	"context"
 	"crypto/sha1"
 	"embed"
 	"encoding/binary"
-	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
 	"html/template"
 	"log"
 	"net/http"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 	"time"
+
+	"quelpoke/internal/pokeapi"
+	"quelpoke/internal/pokeclient"
 )
 
 //go:embed index.tmpl.html
@@ -28,6 +35,8 @@ type indexTemplateParams struct {
 	PokemonSprite string
 }
 
+const shutdownGrace = 5 * time.Second
+
 // env : retourne une valeur d’environnement ou défaut
 func env(name string, def string) string {
 	if v := os.Getenv(name); v != "" {
@@ -37,61 +46,112 @@ func env(name string, def string) string {
 }
 
 func main() {
+	replFlag := flag.Bool("repl", false, "launch the interactive pokedex REPL instead of the HTTP server")
+	flag.Parse()
+
+	client := pokeclient.NewClient()
+	defer client.Close()
+
+	if *replFlag || env("MODE", "") == "repl" {
+		startRepl(client)
+		return
+	}
+
 	addr := env("ADDR", "0.0.0.0")
 	port := env("PORT", "8080")
 	listen := fmt.Sprintf("%s:%s", addr, port)
 
-	log.Printf("🚀 Server running on http://%s", listen)
 	mux := http.NewServeMux()
-	mux.HandleFunc("/", index)
-	if err := http.ListenAndServe(listen, mux); err != nil {
-		log.Fatal(err)
+	mux.HandleFunc("/", index(client))
+	mux.HandleFunc("/api/pokemon", apiPokemon(client))
+	mux.HandleFunc("/locations", locationsIndex(client))
+	mux.HandleFunc("/locations/", locationArea(client))
+
+	// index/apiPokemon chain up to 3 sequential upstream calls on a cold
+	// cache (pokemon -> species -> evolution-chain), each bounded by the
+	// client's own HTTP_TIMEOUT, so WriteTimeout must cover all of them
+	// plus some slack for rendering the response.
+	writeTimeout := 3*pokeclient.Timeout() + 5*time.Second
+
+	server := &http.Server{
+		Addr:              listen,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+		WriteTimeout:      writeTimeout,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		log.Printf("🚀 Server running on http://%s", listen)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal(err)
+		}
+	}()
+
+	<-ctx.Done()
+	stop()
+	log.Println("shutting down...")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownGrace)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("shutdown error: %v", err)
 	}
 }
 
-func index(w http.ResponseWriter, r *http.Request) {
-	start := time.Now()
-	name := r.URL.Query().Get("name")
-	if name == "" {
-		name = "cafard"
-	}
+func index(client *pokeclient.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		ctx := r.Context()
+		name := r.URL.Query().Get("name")
+		if name == "" {
+			name = "cafard"
+		}
 
-	tmpl, err := template.New("index.tmpl.html").Funcs(template.FuncMap{
-		"title": func(s string) string {
-			if s == "" {
-				return ""
-			}
-			return strings.ToUpper(s[:1]) + s[1:]
-		},
-	}).ParseFS(indexTemplateFS, "index.tmpl.html")
-	if err != nil {
-		http.Error(w, err.Error(), 500)
-		return
-	}
+		pid := pokemonID(name, 151)
+		poke, evolutions, err := fetchPokemon(ctx, client, pid)
+		if err != nil {
+			http.Error(w, err.Error(), 500)
+			return
+		}
 
-	pid := pokemonID(name, 151)
-	poke, err := fetchPokemon(pid)
-	if err != nil {
-		http.Error(w, err.Error(), 500)
-		return
-	}
+		if wantsJSON(r) {
+			writeJSON(w, newPokemonResponse(poke, evolutions))
+			return
+		}
 
-	params := indexTemplateParams{
-		Name:          name,
-		Version:       env("VERSION", "cafard-edition"),
-		PokemonID:     pid,
-		PokemonName:   poke.Name,
-		Types:         poke.Types,
-		Stats:         poke.Stats,
-		Evolutions:    poke.Evolutions,
-		PokemonSprite: poke.Sprite,
-	}
+		tmpl, err := template.New("index.tmpl.html").Funcs(template.FuncMap{
+			"title": func(s string) string {
+				if s == "" {
+					return ""
+				}
+				return strings.ToUpper(s[:1]) + s[1:]
+			},
+		}).ParseFS(indexTemplateFS, "index.tmpl.html")
+		if err != nil {
+			http.Error(w, err.Error(), 500)
+			return
+		}
 
-	if err := tmpl.Execute(w, params); err != nil {
-		http.Error(w, err.Error(), 500)
-		return
+		params := indexTemplateParams{
+			Name:          name,
+			Version:       env("VERSION", "cafard-edition"),
+			PokemonID:     pid,
+			PokemonName:   poke.Name,
+			Types:         pokemonTypes(poke),
+			Stats:         pokemonStats(poke),
+			Evolutions:    evolutions,
+			PokemonSprite: poke.Sprites.Other.OfficialArtwork.FrontDefault,
+		}
+
+		if err := tmpl.Execute(w, params); err != nil {
+			http.Error(w, err.Error(), 500)
+			return
+		}
+		log.Printf("✅ Generated page in %s for %s → %s", time.Since(start), name, poke.Name)
 	}
-	log.Printf("✅ Generated page in %s for %s → %s", time.Since(start), name, poke.Name)
 }
 
 func pokemonID(name string, m uint64) uint64 {
@@ -100,115 +160,43 @@ func pokemonID(name string, m uint64) uint64 {
 	return binary.BigEndian.Uint64(h.Sum(nil))%m + 1
 }
 
-// Structs pour PokeAPI
-type pokeAPIResponse struct {
-	Name  string `json:"name"`
-	Types []struct {
-		Type struct {
-			Name string `json:"name"`
-		} `json:"type"`
-	} `json:"types"`
-	Stats []struct {
-		BaseStat int `json:"base_stat"`
-		Stat     struct {
-			Name string `json:"name"`
-		} `json:"stat"`
-	} `json:"stats"`
-	Sprites struct {
-		Other struct {
-			Official struct {
-				Front string `json:"front_default"`
-			} `json:"official-artwork"`
-		} `json:"other"`
-	} `json:"sprites"`
-	Species struct {
-		URL string `json:"url"`
-	} `json:"species"`
+func pokemonTypes(poke pokeapi.Pokemon) []string {
+	types := make([]string, 0, len(poke.Types))
+	for _, t := range poke.Types {
+		types = append(types, t.Type.Name)
+	}
+	return types
 }
 
-type pokemonData struct {
-	Name       string
-	Types      []string
-	Stats      map[string]int
-	Sprite     string
-	Evolutions []string
+func pokemonStats(poke pokeapi.Pokemon) map[string]int {
+	stats := make(map[string]int, len(poke.Stats))
+	for _, s := range poke.Stats {
+		stats[s.Stat.Name] = s.BaseStat
+	}
+	return stats
 }
 
-func fetchPokemon(id uint64) (pokemonData, error) {
-	var result pokemonData
-
-	// Récupère le Pokémon
-	resp, err := http.Get(fmt.Sprintf("https://pokeapi.co/api/v2/pokemon/%d", id))
+// fetchPokemon resolves a Pokemon plus its evolution chain names via client.
+func fetchPokemon(ctx context.Context, client *pokeclient.Client, id uint64) (pokeapi.Pokemon, []string, error) {
+	poke, err := client.GetPokemon(ctx, id)
 	if err != nil {
-		return result, err
-	}
-	defer resp.Body.Close()
-
-	var poke pokeAPIResponse
-	if err := json.NewDecoder(resp.Body).Decode(&poke); err != nil {
-		return result, err
+		return pokeapi.Pokemon{}, nil, err
 	}
 
-	result.Name = poke.Name
-	result.Sprite = poke.Sprites.Other.Official.Front
-	result.Stats = make(map[string]int)
-	for _, s := range poke.Stats {
-		result.Stats[s.Stat.Name] = s.BaseStat
-	}
-	for _, t := range poke.Types {
-		result.Types = append(result.Types, t.Type.Name)
-	}
-
-	// Récupère les évolutions
-	result.Evolutions = fetchEvolutions(poke.Species.URL)
-	return result, nil
+	evolutions := fetchEvolutions(ctx, client, poke.Species.URL)
+	return poke, evolutions, nil
 }
 
-func fetchEvolutions(speciesURL string) []string {
-	resp, err := http.Get(speciesURL)
+func fetchEvolutions(ctx context.Context, client *pokeclient.Client, speciesURL string) []string {
+	species, err := client.GetSpecies(ctx, speciesURL)
 	if err != nil {
 		return nil
 	}
-	defer resp.Body.Close()
 
-	var species struct {
-		EvolutionChain struct {
-			URL string `json:"url"`
-		} `json:"evolution_chain"`
-	}
-	json.NewDecoder(resp.Body).Decode(&species)
-
-	resp2, err := http.Get(species.EvolutionChain.URL)
+	chain, err := client.GetEvolutionChain(ctx, species.EvolutionChain.URL)
 	if err != nil {
 		return nil
 	}
-	defer resp2.Body.Close()
-
-	var chain struct {
-		Chain struct {
-			Species struct {
-				Name string `json:"name"`
-			} `json:"species"`
-			EvolvesTo []struct {
-				Species struct {
-					Name string `json:"name"`
-				} `json:"species"`
-				EvolvesTo []struct {
-					Species struct {
-						Name string `json:"name"`
-					} `json:"species"`
-				} `json:"evolves_to"`
-			} `json:"evolves_to"`
-		} `json:"chain"`
-	}
-	json.NewDecoder(resp2.Body).Decode(&chain)
 
-	evols := []string{chain.Chain.Species.Name}
-	for _, e := range chain.Chain.EvolvesTo {
-		evols = append(evols, e.Species.Name)
-		for _, f := range e.EvolvesTo {
-			evols = append(evols, f.Species.Name)
-		}
-	}
-	return evols
+	return chain.Chain.Names()
 }
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"strings"
+
+	"quelpoke/internal/pokeapi"
+	"quelpoke/internal/pokeclient"
+)
+
+// replConfig carries the state shared across REPL commands: the client used
+// to talk to pokeapi.co, the current location-area pagination cursors, and
+// the pokemon caught so far.
+type replConfig struct {
+	client       *pokeclient.Client
+	nextLocation string
+	prevLocation string
+	pokedex      map[string]pokeapi.Pokemon
+}
+
+type replCommand struct {
+	name        string
+	description string
+	callback    func(*replConfig, []string) error
+}
+
+func replCommands() map[string]replCommand {
+	return map[string]replCommand{
+		"help": {
+			name:        "help",
+			description: "Displays a help message",
+			callback:    commandHelp,
+		},
+		"exit": {
+			name:        "exit",
+			description: "Exit the pokedex",
+			callback:    commandExit,
+		},
+		"map": {
+			name:        "map",
+			description: "Displays the next 20 location areas",
+			callback:    commandMap,
+		},
+		"mapb": {
+			name:        "mapb",
+			description: "Displays the previous 20 location areas",
+			callback:    commandMapb,
+		},
+		"explore": {
+			name:        "explore",
+			description: "Lists the pokemon that can be found in a location area",
+			callback:    commandExplore,
+		},
+		"catch": {
+			name:        "catch",
+			description: "Attempts to catch a pokemon and add it to your pokedex",
+			callback:    commandCatch,
+		},
+		"inspect": {
+			name:        "inspect",
+			description: "Prints the stats and types of a caught pokemon",
+			callback:    commandInspect,
+		},
+		"pokedex": {
+			name:        "pokedex",
+			description: "Lists the pokemon you have caught",
+			callback:    commandPokedex,
+		},
+	}
+}
+
+// startRepl runs the interactive pokedex shell until the user exits or
+// stdin is closed.
+func startRepl(client *pokeclient.Client) {
+	cfg := &replConfig{
+		client:  client,
+		pokedex: make(map[string]pokeapi.Pokemon),
+	}
+	commands := replCommands()
+
+	scanner := bufio.NewScanner(os.Stdin)
+	fmt.Print("pokedex > ")
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			fmt.Print("pokedex > ")
+			continue
+		}
+
+		cmd, ok := commands[fields[0]]
+		if !ok {
+			fmt.Println("Unknown command")
+			fmt.Print("pokedex > ")
+			continue
+		}
+
+		if err := cmd.callback(cfg, fields[1:]); err != nil {
+			fmt.Println(err)
+		}
+		fmt.Print("pokedex > ")
+	}
+}
+
+func commandHelp(cfg *replConfig, args []string) error {
+	fmt.Println("Welcome to the Pokedex!")
+	fmt.Println("Usage:")
+	fmt.Println()
+	for _, cmd := range replCommands() {
+		fmt.Printf("%s: %s\n", cmd.name, cmd.description)
+	}
+	return nil
+}
+
+func commandExit(cfg *replConfig, args []string) error {
+	os.Exit(0)
+	return nil
+}
+
+func commandMap(cfg *replConfig, args []string) error {
+	list, err := cfg.client.ListLocationAreas(context.Background(), cfg.nextLocation)
+	if err != nil {
+		return err
+	}
+	return printLocationAreaPage(cfg, list)
+}
+
+func commandMapb(cfg *replConfig, args []string) error {
+	if cfg.prevLocation == "" {
+		return fmt.Errorf("you're on the first page")
+	}
+	list, err := cfg.client.ListLocationAreas(context.Background(), cfg.prevLocation)
+	if err != nil {
+		return err
+	}
+	return printLocationAreaPage(cfg, list)
+}
+
+func printLocationAreaPage(cfg *replConfig, list pokeapi.NamedAPIResourceList) error {
+	cfg.nextLocation = deref(list.Next)
+	cfg.prevLocation = deref(list.Previous)
+	for _, area := range list.Results {
+		fmt.Println(area.Name)
+	}
+	return nil
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func commandExplore(cfg *replConfig, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: explore <area>")
+	}
+
+	fmt.Printf("Exploring %s...\n", args[0])
+	area, err := cfg.client.GetLocationArea(context.Background(), args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Found Pokemon:")
+	for _, encounter := range area.PokemonEncounters {
+		fmt.Printf(" - %s\n", encounter.Pokemon.Name)
+	}
+	return nil
+}
+
+func commandCatch(cfg *replConfig, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: catch <pokemon>")
+	}
+	name := args[0]
+
+	poke, err := cfg.client.GetPokemonByName(context.Background(), name)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Throwing a Pokeball at %s...\n", name)
+	if rand.Intn(poke.BaseExperience+40) >= 40 {
+		fmt.Printf("%s escaped!\n", name)
+		return nil
+	}
+
+	fmt.Printf("%s was caught!\n", name)
+	cfg.pokedex[name] = poke
+	return nil
+}
+
+func commandInspect(cfg *replConfig, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: inspect <pokemon>")
+	}
+	name := args[0]
+
+	poke, ok := cfg.pokedex[name]
+	if !ok {
+		return fmt.Errorf("you have not caught %s", name)
+	}
+
+	fmt.Printf("Name: %s\n", poke.Name)
+	fmt.Println("Stats:")
+	for _, stat := range poke.Stats {
+		fmt.Printf("  -%s: %d\n", stat.Stat.Name, stat.BaseStat)
+	}
+	fmt.Println("Types:")
+	for _, t := range poke.Types {
+		fmt.Printf("  - %s\n", t.Type.Name)
+	}
+	return nil
+}
+
+func commandPokedex(cfg *replConfig, args []string) error {
+	fmt.Println("Your Pokedex:")
+	for name := range cfg.pokedex {
+		fmt.Printf(" - %s\n", name)
+	}
+	return nil
+}